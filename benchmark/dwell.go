@@ -0,0 +1,208 @@
+// Package benchmark measures how long the host's WiFi radio actually
+// dwells on a channel during an active scan, adapted from the ChromiumOS
+// channel-switch dwell-time (CSDT) test idea.
+package benchmark
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net"
+	"os/exec"
+	"sort"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+// Config describes one dwell-time benchmark run.
+type Config struct {
+	// ScanInterface is the NIC that performs the targeted scan under test.
+	ScanInterface string
+	// CaptureInterface is a second monitor-mode NIC, physically separate
+	// from ScanInterface, used to observe ScanInterface's own probe
+	// request transmissions over the air: an active scan only transmits
+	// probe requests on a channel while it's actually dwelling there, so
+	// the first/last-seen window of those frames on CaptureInterface is
+	// the dwell time.
+	CaptureInterface string
+	// FreqMHz is the channel frequency to scan, in MHz (e.g. 2437 for
+	// channel 6).
+	FreqMHz int
+	// Reps is how many times to repeat the scan+capture cycle.
+	Reps int
+	// MinWindow and MaxWindow bound the acceptable dwell time; a
+	// measurement outside this window is reported as a failure.
+	MinWindow time.Duration
+	MaxWindow time.Duration
+}
+
+// DefaultConfig returns sane defaults matching the ChromiumOS CSDT test.
+func DefaultConfig(scanIface, captureIface string, freqMHz int) Config {
+	return Config{
+		ScanInterface:    scanIface,
+		CaptureInterface: captureIface,
+		FreqMHz:          freqMHz,
+		Reps:             10,
+		MinWindow:        5 * time.Millisecond,
+		MaxWindow:        250 * time.Millisecond,
+	}
+}
+
+// Result is a single repetition's measured dwell time plus whether it
+// fell inside the configured [MinWindow, MaxWindow].
+type Result struct {
+	DwellTime time.Duration
+	InWindow  bool
+}
+
+// Distribution summarizes dwell times across repetitions.
+type Distribution struct {
+	Min    time.Duration
+	Median time.Duration
+	P95    time.Duration
+	Max    time.Duration
+	Failed int // repetitions whose dwell time fell outside [MinWindow, MaxWindow]
+}
+
+// Run executes cfg.Reps scan+capture cycles and returns the per-rep
+// results plus the aggregate distribution.
+func Run(cfg Config) ([]Result, Distribution, error) {
+	dutAddr, err := resolveHardwareAddr(cfg.ScanInterface)
+	if err != nil {
+		return nil, Distribution{}, fmt.Errorf("resolving %s's hardware address: %w", cfg.ScanInterface, err)
+	}
+
+	results := make([]Result, 0, cfg.Reps)
+
+	for i := 0; i < cfg.Reps; i++ {
+		dwell, err := runOnce(cfg, dutAddr)
+		if err != nil {
+			return results, Distribution{}, fmt.Errorf("rep %d: %w", i, err)
+		}
+		results = append(results, Result{
+			DwellTime: dwell,
+			InWindow:  dwell >= cfg.MinWindow && dwell <= cfg.MaxWindow,
+		})
+	}
+
+	return results, summarize(results, cfg), nil
+}
+
+// resolveHardwareAddr looks up the MAC address ScanInterface transmits
+// probe requests from, so runOnce can pick its frames out of everything
+// else CaptureInterface sees over the air.
+func resolveHardwareAddr(iface string) (net.HardwareAddr, error) {
+	ifi, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, fmt.Errorf("looking up interface %s: %w", iface, err)
+	}
+	return ifi.HardwareAddr, nil
+}
+
+func runOnce(cfg Config, dutAddr net.HardwareAddr) (time.Duration, error) {
+	handle, err := pcap.OpenLive(cfg.CaptureInterface, 2048, true, pcap.BlockForever)
+	if err != nil {
+		return 0, fmt.Errorf("opening capture on %s: %w", cfg.CaptureInterface, err)
+	}
+	defer handle.Close()
+	if err := handle.SetBPFFilter("type mgt subtype probe-req"); err != nil {
+		return 0, fmt.Errorf("setting capture filter: %w", err)
+	}
+
+	var firstSeen, lastSeen time.Time
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+	scanDone := make(chan error, 1)
+	go func() { scanDone <- triggerScan(cfg.ScanInterface, cfg.FreqMHz) }()
+
+	timeout := time.After(cfg.MaxWindow * 4)
+	for {
+		select {
+		case packet, ok := <-packetSource.Packets():
+			if !ok {
+				return 0, fmt.Errorf("capture on %s ended before the scan completed", cfg.CaptureInterface)
+			}
+			if !isProbeRequestFrom(packet, dutAddr) {
+				continue
+			}
+			now := time.Now()
+			if firstSeen.IsZero() {
+				firstSeen = now
+			}
+			lastSeen = now
+		case err := <-scanDone:
+			if err != nil {
+				return 0, err
+			}
+			if firstSeen.IsZero() {
+				return 0, fmt.Errorf("no probe requests from %s were observed on %s", cfg.ScanInterface, cfg.CaptureInterface)
+			}
+			return lastSeen.Sub(firstSeen), nil
+		case <-timeout:
+			return 0, fmt.Errorf("timed out waiting for scan to complete on %s", cfg.ScanInterface)
+		}
+	}
+}
+
+// isProbeRequestFrom reports whether packet is an 802.11 probe request
+// transmitted by src.
+func isProbeRequestFrom(packet gopacket.Packet, src net.HardwareAddr) bool {
+	if packet.Layer(layers.LayerTypeDot11MgmtProbeReq) == nil {
+		return false
+	}
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(dot11.Address2, src)
+}
+
+// triggerScan issues a single-channel active scan on iface, blocking
+// until it completes.
+func triggerScan(iface string, freqMHz int) error {
+	cmd := exec.Command("iw", "dev", iface, "scan", "freq", fmt.Sprintf("%d", freqMHz))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("iw scan failed: %v\n%s", err, out)
+	}
+	return nil
+}
+
+func summarize(results []Result, cfg Config) Distribution {
+	if len(results) == 0 {
+		return Distribution{}
+	}
+	durations := make([]time.Duration, len(results))
+	failed := 0
+	for i, r := range results {
+		durations[i] = r.DwellTime
+		if !r.InWindow {
+			failed++
+		}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	return Distribution{
+		Min:    durations[0],
+		Median: percentile(durations, 0.5),
+		P95:    percentile(durations, 0.95),
+		Max:    durations[len(durations)-1],
+		Failed: failed,
+	}
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MaVeN-13TTN/wifi-speed-cli/benchmark"
+)
+
+// runBenchmark dispatches the `benchmark` command's subcommands.
+func runBenchmark(args []string) {
+	if len(args) < 1 {
+		fmt.Println("Usage: ./wifi-speed-cli benchmark dwell --scan-iface <iface> --capture-iface <iface> --freq <mhz>")
+		return
+	}
+
+	switch args[0] {
+	case "dwell":
+		runBenchmarkDwell(args[1:])
+	default:
+		fmt.Printf("Invalid benchmark subcommand %q. Use 'dwell'.\n", args[0])
+	}
+}
+
+// runBenchmarkDwell implements `benchmark dwell`: it measures how long
+// the scanning NIC's radio actually sits on a channel during a targeted
+// active scan, by observing that NIC's own probe-request transmissions
+// over the air on a second, physically separate monitor-mode interface.
+func runBenchmarkDwell(args []string) {
+	fs := flag.NewFlagSet("benchmark dwell", flag.ExitOnError)
+	scanIface := fs.String("scan-iface", "", "interface performing the scan under test")
+	captureIface := fs.String("capture-iface", "", "second monitor-mode interface, physically separate from --scan-iface, used to observe its probe-request transmissions")
+	freq := fs.Int("freq", 2437, "channel frequency to scan, in MHz")
+	reps := fs.Int("reps", 10, "number of scan+capture repetitions")
+	minMs := fs.Int("min-ms", 5, "minimum acceptable dwell time, in milliseconds")
+	maxMs := fs.Int("max-ms", 250, "maximum acceptable dwell time, in milliseconds")
+	fs.Parse(args)
+
+	if *scanIface == "" || *captureIface == "" {
+		fmt.Println("Error: --scan-iface and --capture-iface are both required")
+		return
+	}
+
+	if !checkIsRoot() {
+		fmt.Println("❌ Error: dwell-time benchmarking requires root privileges.")
+		fmt.Println("Please run the command with sudo.")
+		return
+	}
+
+	cfg := benchmark.DefaultConfig(*scanIface, *captureIface, *freq)
+	cfg.Reps = *reps
+	cfg.MinWindow = msToDuration(*minMs)
+	cfg.MaxWindow = msToDuration(*maxMs)
+
+	fmt.Printf("Running %d dwell-time repetitions: scanning %s on %d MHz, observing via %s...\n",
+		cfg.Reps, cfg.ScanInterface, cfg.FreqMHz, cfg.CaptureInterface)
+
+	results, dist, err := benchmark.Run(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running dwell-time benchmark: %v\n", err)
+		return
+	}
+
+	for i, r := range results {
+		status := "ok"
+		if !r.InWindow {
+			status = "OUT OF WINDOW"
+		}
+		fmt.Printf("  rep %2d: dwell=%-10s %s\n", i+1, r.DwellTime, status)
+	}
+
+	fmt.Println("\nDwell time distribution:")
+	fmt.Printf("  min:    %s\n", dist.Min)
+	fmt.Printf("  median: %s\n", dist.Median)
+	fmt.Printf("  p95:    %s\n", dist.P95)
+	fmt.Printf("  max:    %s\n", dist.Max)
+	fmt.Printf("  failed: %d/%d (outside [%dms, %dms])\n", dist.Failed, len(results), *minMs, *maxMs)
+}
+
+func msToDuration(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
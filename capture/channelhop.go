@@ -0,0 +1,80 @@
+// Package capture implements a monitor-mode 802.11 frame capture for
+// passive WiFi scanning: a ChannelHopper sweeps the radio across a set of
+// channels while a Scanner decodes beacon and probe-response frames off
+// the wire with gopacket/pcap.
+package capture
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// DefaultChannels is the channel list used when the caller doesn't supply
+// one: the three non-overlapping 2.4 GHz channels plus the commonly used
+// 5 GHz UNII-1 and UNII-3 channels.
+var DefaultChannels = []int{1, 6, 11, 36, 40, 44, 48, 149, 153, 157, 161, 165}
+
+// DefaultDwell is how long the hopper sits on each channel before moving
+// to the next one.
+const DefaultDwell = 250 * time.Millisecond
+
+// ChannelHopper cycles a monitor-mode interface through a list of
+// channels, dwelling on each one for a fixed duration.
+type ChannelHopper struct {
+	Interface string
+	Channels  []int
+	Dwell     time.Duration
+}
+
+// NewChannelHopper builds a ChannelHopper with the package defaults
+// filled in for any zero-valued fields.
+func NewChannelHopper(iface string, channels []int, dwell time.Duration) *ChannelHopper {
+	if len(channels) == 0 {
+		channels = DefaultChannels
+	}
+	if dwell <= 0 {
+		dwell = DefaultDwell
+	}
+	return &ChannelHopper{Interface: iface, Channels: channels, Dwell: dwell}
+}
+
+// Run sweeps through Channels once, setting the interface to each one in
+// turn and sleeping for Dwell before moving on. It returns when ctx is
+// done or the sweep completes.
+func (h *ChannelHopper) Run(ctx context.Context) error {
+	for _, ch := range h.Channels {
+		if err := h.setChannel(ch); err != nil {
+			return fmt.Errorf("setting channel %d on %s: %w", ch, h.Interface, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(h.Dwell):
+		}
+	}
+	return nil
+}
+
+// RunContinuous calls Run repeatedly until ctx is done, giving a
+// continuous channel sweep for `--follow` style operation.
+func (h *ChannelHopper) RunContinuous(ctx context.Context) error {
+	for {
+		if err := h.Run(ctx); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+func (h *ChannelHopper) setChannel(channel int) error {
+	cmd := exec.Command("iw", "dev", h.Interface, "set", "channel", fmt.Sprintf("%d", channel))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("iw set channel failed: %v\n%s", err, out)
+	}
+	return nil
+}
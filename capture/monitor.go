@@ -0,0 +1,63 @@
+package capture
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// EnableMonitorMode puts iface into monitor mode, bringing the link down
+// and back up around the type change as `iw` requires. It returns a
+// restore function that switches the interface back to whatever mode it
+// was in before, which callers should defer so the adapter isn't left in
+// monitor mode after the capture ends.
+func EnableMonitorMode(iface string) (restore func() error, err error) {
+	originalType, err := interfaceType(iface)
+	if err != nil {
+		return nil, fmt.Errorf("reading current mode of %s: %w", iface, err)
+	}
+
+	if originalType == "monitor" {
+		return func() error { return nil }, nil
+	}
+
+	if err := setInterfaceType(iface, "monitor"); err != nil {
+		return nil, fmt.Errorf("switching %s to monitor mode: %w", iface, err)
+	}
+
+	return func() error {
+		return setInterfaceType(iface, originalType)
+	}, nil
+}
+
+// interfaceType reports the current `iw` mode of iface (e.g. "managed"
+// or "monitor").
+func interfaceType(iface string) (string, error) {
+	out, err := exec.Command("iw", "dev", iface, "info").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("iw dev info failed: %v\n%s", err, out)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "type ") {
+			return strings.TrimPrefix(line, "type "), nil
+		}
+	}
+	return "", fmt.Errorf("could not determine interface type from `iw dev %s info`", iface)
+}
+
+// setInterfaceType brings iface down, switches its `iw` type, and brings
+// it back up, mirroring the standard `ip link ... / iw ... set type ...`
+// monitor-mode dance.
+func setInterfaceType(iface, mode string) error {
+	if out, err := exec.Command("ip", "link", "set", iface, "down").CombinedOutput(); err != nil {
+		return fmt.Errorf("bringing %s down: %v\n%s", iface, err, out)
+	}
+	if out, err := exec.Command("iw", "dev", iface, "set", "type", mode).CombinedOutput(); err != nil {
+		return fmt.Errorf("setting %s to type %s: %v\n%s", iface, mode, err, out)
+	}
+	if out, err := exec.Command("ip", "link", "set", iface, "up").CombinedOutput(); err != nil {
+		return fmt.Errorf("bringing %s up: %v\n%s", iface, err, out)
+	}
+	return nil
+}
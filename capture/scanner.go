@@ -0,0 +1,177 @@
+package capture
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+// Observation is a single access point as seen over the air: unlike an
+// nmcli-based scan it is built entirely from captured beacon and
+// probe-response frames, so hidden networks (empty SSID in the beacon)
+// show up too.
+type Observation struct {
+	SSID      string
+	BSSID     string
+	Channel   int
+	RSSI      int
+	Rates     []string
+	Security  string
+	LastSeen  time.Time
+	FrameType string // "beacon" or "probe-resp"
+}
+
+// Scanner captures 802.11 management frames on a monitor-mode interface
+// and aggregates them into per-BSSID Observations.
+type Scanner struct {
+	Interface string
+
+	mu           sync.Mutex
+	observations map[string]*Observation
+}
+
+// NewScanner builds a capture on iface. Callers are expected to have
+// already switched iface into monitor mode (see EnableMonitorMode)
+// before calling Sweep.
+func NewScanner(iface string) *Scanner {
+	return &Scanner{
+		Interface:    iface,
+		observations: make(map[string]*Observation),
+	}
+}
+
+// Sweep captures frames for the given duration (or until stop is
+// closed, if non-nil) and returns every BSSID observed, aggregated with
+// the last-seen timestamp and the highest RSSI seen for that BSSID.
+func (s *Scanner) Sweep(duration time.Duration, stop <-chan struct{}) ([]Observation, error) {
+	handle, err := pcap.OpenLive(s.Interface, 2048, true, pcap.BlockForever)
+	if err != nil {
+		return nil, fmt.Errorf("opening pcap capture on %s: %w", s.Interface, err)
+	}
+	defer handle.Close()
+
+	if err := handle.SetBPFFilter("type mgt subtype beacon or type mgt subtype probe-resp"); err != nil {
+		return nil, fmt.Errorf("setting capture filter: %w", err)
+	}
+
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+
+	var timeout <-chan time.Time
+	if duration > 0 {
+		timeout = time.After(duration)
+	}
+
+	for {
+		select {
+		case packet, ok := <-packetSource.Packets():
+			if !ok {
+				return s.results(), nil
+			}
+			s.handlePacket(packet)
+		case <-timeout:
+			return s.results(), nil
+		case <-stop:
+			return s.results(), nil
+		}
+	}
+}
+
+func (s *Scanner) handlePacket(packet gopacket.Packet) {
+	radiotapLayer := packet.Layer(layers.LayerTypeRadioTap)
+	dot11Layer := packet.Layer(layers.LayerTypeDot11)
+	if radiotapLayer == nil || dot11Layer == nil {
+		return
+	}
+	radiotap, _ := radiotapLayer.(*layers.RadioTap)
+	dot11, _ := dot11Layer.(*layers.Dot11)
+
+	var obs Observation
+	obs.BSSID = dot11.Address3.String()
+	obs.RSSI = int(radiotap.DBMAntennaSignal)
+
+	if beaconLayer := packet.Layer(layers.LayerTypeDot11MgmtBeacon); beaconLayer != nil {
+		obs.FrameType = "beacon"
+	} else if probeLayer := packet.Layer(layers.LayerTypeDot11MgmtProbeResp); probeLayer != nil {
+		obs.FrameType = "probe-resp"
+	} else {
+		return
+	}
+
+	for _, l := range packet.Layers() {
+		ie, ok := l.(*layers.Dot11InformationElement)
+		if !ok {
+			continue
+		}
+		switch ie.ID {
+		case layers.Dot11InformationElementIDSSID:
+			obs.SSID = string(ie.Info)
+		case layers.Dot11InformationElementIDDSSet:
+			if len(ie.Info) > 0 {
+				obs.Channel = int(ie.Info[0])
+			}
+		case layers.Dot11InformationElementIDRates:
+			obs.Rates = decodeRates(ie.Info)
+		case layers.Dot11InformationElementIDRSNInfo:
+			obs.Security = "WPA2/RSN"
+		case layers.Dot11InformationElementIDVendor:
+			if isWPAVendorIE(ie.Info) && obs.Security == "" {
+				obs.Security = "WPA"
+			}
+		}
+	}
+	if obs.Security == "" {
+		obs.Security = "Open"
+	}
+	if obs.SSID == "" {
+		obs.SSID = "[Hidden Network]"
+	}
+
+	s.record(obs)
+}
+
+func (s *Scanner) record(obs Observation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obs.LastSeen = time.Now()
+	existing, ok := s.observations[obs.BSSID]
+	if !ok || obs.RSSI > existing.RSSI {
+		o := obs
+		s.observations[obs.BSSID] = &o
+		return
+	}
+	existing.LastSeen = obs.LastSeen
+	if obs.SSID != "[Hidden Network]" {
+		existing.SSID = obs.SSID
+	}
+}
+
+func (s *Scanner) results() []Observation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Observation, 0, len(s.observations))
+	for _, o := range s.observations {
+		out = append(out, *o)
+	}
+	return out
+}
+
+func decodeRates(raw []byte) []string {
+	rates := make([]string, 0, len(raw))
+	for _, b := range raw {
+		mbps := float64(b&0x7f) * 0.5
+		rates = append(rates, fmt.Sprintf("%.1f", mbps))
+	}
+	return rates
+}
+
+// isWPAVendorIE reports whether a vendor-specific information element
+// carries the Microsoft WPA OUI (00:50:F2) with type 1.
+func isWPAVendorIE(info []byte) bool {
+	return len(info) >= 4 && info[0] == 0x00 && info[1] == 0x50 && info[2] == 0xF2 && info[3] == 0x01
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	speedtest "github.com/showwin/speedtest-go/speedtest"
+
+	"github.com/MaVeN-13TTN/wifi-speed-cli/wlan"
+)
+
+// runConnect implements the `connect` subcommand: it drives a
+// wlan.Machine through scanning, joining, authenticating, and
+// associating with the strongest known SSID in range, automatically
+// running a speed test and recording per-BSSID history once connected.
+func runConnect(args []string) {
+	fs := flag.NewFlagSet("connect", flag.ExitOnError)
+	knownPath := fs.String("known", "", "path to known networks YAML (default: ~/.config/wifi-speed-cli/known.yaml)")
+	timeout := fs.Duration("timeout", 2*time.Minute, "overall time budget for the connection attempt")
+	fs.Parse(args)
+
+	if *knownPath == "" {
+		p, err := wlan.DefaultKnownNetworksPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		*knownPath = p
+	}
+
+	known, err := wlan.LoadKnownNetworks(*knownPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading known networks from %s: %v\n", *knownPath, err)
+		return
+	}
+
+	historyPath, err := wlan.DefaultHistoryPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	history, err := wlan.LoadHistory(historyPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading BSSID history: %v\n", err)
+		return
+	}
+
+	hooks := wlan.Hooks{
+		Scan:      scanCandidates,
+		Connect:   connectViaNmcli,
+		TestSpeed: runSpeedTestForConnect,
+	}
+
+	const stateTimeout = 30 * time.Second
+	machine := wlan.NewMachine(wlan.NewScanningState(hooks, known, history, historyPath, stateTimeout), stateTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	fmt.Println("Looking for the strongest known network in range...")
+	if err := machine.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Connection attempt failed: %v\n", err)
+		return
+	}
+
+	switch machine.Current.Name() {
+	case wlan.StateAssociated:
+		fmt.Println("Connected successfully.")
+	case wlan.StateFailed:
+		reason, _ := wlan.FailureReason(machine.Current)
+		fmt.Printf("Could not connect: %s\n", reason)
+	default:
+		fmt.Printf("Stopped in state %s\n", machine.Current.Name())
+	}
+}
+
+// scanCandidates runs a plain nmcli scan and returns the results as
+// wlan.Candidate values, reusing the same parser as `scan`.
+func scanCandidates(ctx context.Context) ([]wlan.Candidate, error) {
+	cmd := exec.CommandContext(ctx, "nmcli", "-c", "no", "device", "wifi", "list", "--rescan", "yes")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("nmcli scan failed: %v\n%s", err, output)
+	}
+
+	var candidates []wlan.Candidate
+	for i, line := range strings.Split(string(output), "\n") {
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+		network, err := parseNetworkLine(line)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, wlan.Candidate{
+			SSID:      network.SSID,
+			BSSID:     network.BSSID,
+			Channel:   network.Channel,
+			SignalDBm: network.SignalDBm,
+		})
+	}
+	return candidates, nil
+}
+
+// connectViaNmcli joins ssid using nmcli's own connection management.
+func connectViaNmcli(ctx context.Context, ssid, psk string) error {
+	args := []string{"device", "wifi", "connect", ssid}
+	if psk != "" {
+		args = append(args, "password", psk)
+	}
+	cmd := exec.CommandContext(ctx, "nmcli", args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("nmcli connect to %q failed: %v\n%s", ssid, err, out)
+	}
+	return nil
+}
+
+// runSpeedTestForConnect runs the same download/upload test as
+// `speedtest`, but returns the results instead of printing them so the
+// connect state machine can record them to BSSID history.
+func runSpeedTestForConnect(ctx context.Context) (downloadMbps, uploadMbps float64, err error) {
+	downloadMbps, uploadMbps, _, err = runSpeedTestWithLatency(ctx)
+	return downloadMbps, uploadMbps, err
+}
+
+// runSpeedTestWithLatency is the shared implementation behind
+// runSpeedTestForConnect and the `serve` exporter: it runs one
+// download/upload/latency cycle and returns the results instead of
+// printing them.
+func runSpeedTestWithLatency(ctx context.Context) (downloadMbps, uploadMbps, latencyMs float64, err error) {
+	if _, err = speedtest.FetchUserInfo(); err != nil {
+		return 0, 0, 0, fmt.Errorf("fetching user info: %w", err)
+	}
+
+	servers, err := speedtest.FetchServers()
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("fetching servers: %w", err)
+	}
+	if len(servers) == 0 {
+		return 0, 0, 0, fmt.Errorf("no speed test servers available")
+	}
+
+	target := servers[0]
+	if err := target.DownloadTest(); err != nil {
+		return 0, 0, 0, fmt.Errorf("download test: %w", err)
+	}
+	if err := target.UploadTest(); err != nil {
+		return 0, 0, 0, fmt.Errorf("upload test: %w", err)
+	}
+
+	dl := float64(target.DLSpeed)
+	ul := float64(target.ULSpeed)
+	if dl > 10000 {
+		dl /= 1000000
+	}
+	if ul > 10000 {
+		ul /= 1000000
+	}
+	latency := float64(target.Latency) / float64(time.Millisecond)
+	return dl, ul, latency, nil
+}
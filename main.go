@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/schollz/wifiscan"
 	speedtest "github.com/showwin/speedtest-go/speedtest"
+
+	"github.com/MaVeN-13TTN/wifi-speed-cli/tui"
 )
 
 func checkIsRoot() bool {
@@ -28,6 +31,7 @@ func checkIsRoot() bool {
 type WiFiNetwork struct {
 	SSID         string
 	BSSID        string
+	Channel      int // 0 if unknown, e.g. when sourced from the wifiscan fallback
 	Signal       int
 	SignalDBm    int
 	Quality      string
@@ -35,12 +39,15 @@ type WiFiNetwork struct {
 	InUse        bool
 }
 
-// scanWithNmcli attempts to scan WiFi networks using the nmcli command
-func scanWithNmcli() error {
+// collectNmcliNetworks scans WiFi networks using the nmcli command and
+// returns them sorted by signal strength (strongest first). Unlike the
+// old scanWithNmcli, it doesn't print anything itself — callers render
+// the result through the tui package.
+func collectNmcliNetworks() ([]WiFiNetwork, error) {
 	// Check if nmcli is available
 	_, err := exec.LookPath("nmcli")
 	if err != nil {
-		return fmt.Errorf("nmcli not found: %v", err)
+		return nil, fmt.Errorf("nmcli not found: %v", err)
 	}
 
 	// Use a channel to receive processed network data
@@ -111,15 +118,6 @@ func scanWithNmcli() error {
 		}()
 	}()
 
-	// Wait a bit to ensure we show the header first
-	time.Sleep(100 * time.Millisecond)
-
-	// Display the header
-	fmt.Println("Available Wi-Fi Networks:")
-	fmt.Println("-------------------------")
-	fmt.Printf("%-30s %-20s %-20s %-15s\n", "SSID", "MAC Address", "Signal Strength", "Quality")
-	fmt.Println("-------------------------------------------------------------------------")
-
 	// Collect the networks
 	var networks []WiFiNetwork
 
@@ -127,37 +125,18 @@ func scanWithNmcli() error {
 	for {
 		select {
 		case err := <-errorChan:
-			return err
+			return nil, err
 		case network, ok := <-networkChan:
 			if !ok {
 				// Channel closed, all networks processed
-				// Sort networks by signal strength (strongest first)
 				sort.Slice(networks, func(i, j int) bool {
 					return networks[i].Signal > networks[j].Signal
 				})
-
-				// Print the networks
-				for _, network := range networks {
-					fmt.Printf("%-30s %-20s %-20s %-15s\n",
-						network.SSID,
-						network.BSSID,
-						fmt.Sprintf("%d%% (%d dBm)", network.Signal, network.SignalDBm),
-						fmt.Sprintf("%s (%s)", network.Quality, network.QualityLabel))
-				}
-
-				if len(networks) == 0 {
-					fmt.Println("No WiFi networks found. Make sure your WiFi adapter is enabled.")
-				}
-
-				return nil
+				return networks, nil
 			}
 			networks = append(networks, network)
 		}
 	}
-
-	// This code is unreachable because the function always returns
-	// from within the select statement above when the channel is closed
-	// or an error occurs
 }
 
 // parseNetworkLine parses a single line from nmcli output into a WiFiNetwork struct
@@ -193,12 +172,15 @@ func parseNetworkLine(line string) (WiFiNetwork, error) {
 	}
 
 	// If we found "Infra", use that as the SSID end, otherwise use the original calculation
+	var modeIndex int
 	if infraIndex != -1 {
 		network.SSID = strings.Join(fields[startIdx+1:infraIndex], " ")
+		modeIndex = infraIndex
 	} else {
 		// Fallback to original calculation
 		ssidEnd := len(fields) - 6 // Last 6 fields are: MODE, CHAN, RATE, SIGNAL, BARS, SECURITY
 		network.SSID = strings.Join(fields[startIdx+1:ssidEnd], " ")
+		modeIndex = ssidEnd
 	}
 
 	// Handle hidden networks
@@ -206,6 +188,13 @@ func parseNetworkLine(line string) (WiFiNetwork, error) {
 		network.SSID = "[Hidden Network]"
 	}
 
+	// CHAN immediately follows MODE ("Infra")
+	if chanIndex := modeIndex + 1; chanIndex < len(fields) {
+		if channel, err := strconv.Atoi(fields[chanIndex]); err == nil {
+			network.Channel = channel
+		}
+	}
+
 	// Signal strength is 2nd from the end before BARS and SECURITY
 	signalStr := fields[len(fields)-3]
 	signal, err := strconv.Atoi(signalStr)
@@ -238,7 +227,16 @@ func getSignalQualityLabelFromPercent(percent int) string {
 	return "Very Poor"
 }
 
-func scanWiFi() {
+func scanWiFi(args []string) {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	noColor := fs.Bool("no-color", false, "disable ANSI colors in the output table")
+	sortBy := fs.String("sort", "signal", "sort networks by: signal, ssid, or channel")
+	reverse := fs.Bool("reverse", false, "reverse the sort order")
+	watch := fs.Duration("watch", 0, "redraw the table every interval instead of scanning once (e.g. --watch=2s)")
+	fs.Parse(args)
+
+	opts := tui.Options{NoColor: *noColor, SortBy: tui.SortKey(*sortBy), Reverse: *reverse}
+
 	// Check if running as root
 	if !checkIsRoot() {
 		fmt.Println("❌ Error: WiFi scanning requires root privileges.")
@@ -246,83 +244,120 @@ func scanWiFi() {
 		return
 	}
 
-	fmt.Println("Scanning for WiFi networks...")
+	fetch := func() ([]tui.Network, error) {
+		return fetchScanNetworks()
+	}
 
-	// First attempt: try nmcli (more reliable for showing actual SSIDs)
-	err := scanWithNmcli()
-	if err != nil {
-		fmt.Printf("Error with primary scanning method (nmcli): %v\n", err)
-		fmt.Println("Trying alternative scanning method...")
+	color := tui.UseColor(opts, os.Stdout)
 
-		// Second attempt: try wifiscan library
-		networks, err := wifiscan.Scan()
-		if err != nil {
-			fmt.Printf("Error with alternative scanning method: %v\n", err)
-			fmt.Println("\nPossible causes:")
-			fmt.Println("- WiFi adapter might be disabled")
-			fmt.Println("- Required dependencies might be missing (try: sudo apt install network-manager)")
-			fmt.Println("- Permission issues with network interfaces")
-			fmt.Println("\nTroubleshooting:")
-			fmt.Println("1. Ensure WiFi is enabled: rfkill unblock wifi")
-			fmt.Println("2. Check if NetworkManager is running: systemctl status NetworkManager")
-			fmt.Println("3. Check available WiFi adapters: ip link show")
-			return
-		}
+	if *watch > 0 {
+		fmt.Println("Scanning for WiFi networks (watch mode, press Ctrl+C to stop)...")
+		tui.Watch(os.Stdout, fetch, opts, color, *watch, nil)
+		return
+	}
 
-		// Sort networks by signal strength (strongest first)
-		sort.Slice(networks, func(i, j int) bool {
-			return networks[i].RSSI > networks[j].RSSI
-		})
+	fmt.Println("Scanning for WiFi networks...")
+	networks, err := fetch()
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	tui.Render(os.Stdout, networks, opts, color)
+}
 
-		fmt.Println("Available Wi-Fi Networks:")
-		fmt.Println("-------------------------")
-		fmt.Printf("%-30s %-20s %-20s %-15s\n", "SSID", "MAC Address", "Signal Strength", "Quality")
-		fmt.Println("-------------------------------------------------------------------------")
+// fetchScanNetworks tries nmcli first (more reliable for showing actual
+// SSIDs) and falls back to the wifiscan library, converting either
+// result into the tui package's shared Network type.
+func fetchScanNetworks() ([]tui.Network, error) {
+	networks, err := collectNmcliNetworks()
+	if err == nil {
+		return toTUINetworks(networks), nil
+	}
+	fmt.Printf("Error with primary scanning method (nmcli): %v\n", err)
+	fmt.Println("Trying alternative scanning method...")
+
+	fallback, fallbackErr := collectWifiscanNetworks()
+	if fallbackErr != nil {
+		return nil, fmt.Errorf("error with alternative scanning method: %v\n\n"+
+			"Possible causes:\n"+
+			"- WiFi adapter might be disabled\n"+
+			"- Required dependencies might be missing (try: sudo apt install network-manager)\n"+
+			"- Permission issues with network interfaces\n\n"+
+			"Troubleshooting:\n"+
+			"1. Ensure WiFi is enabled: rfkill unblock wifi\n"+
+			"2. Check if NetworkManager is running: systemctl status NetworkManager\n"+
+			"3. Check available WiFi adapters: ip link show", fallbackErr)
+	}
+	return toTUINetworks(fallback), nil
+}
 
-		uniqueNetworks := make(map[string]bool)
-		for _, network := range networks {
-			// Skip duplicate entries
-			if uniqueNetworks[network.SSID] {
-				continue
-			}
-			uniqueNetworks[network.SSID] = true
-
-			// Determine SSID and MAC address
-			ssid := network.SSID
-			macAddress := ""
-
-			// Check if it looks like a MAC address (contains ":" or has hexadecimal format)
-			if strings.Contains(ssid, ":") || isLikelyMacAddress(ssid) {
-				// This is likely a MAC address for a hidden network
-				macAddress = ssid
-				ssid = "[Hidden Network]"
-			} else {
-				// For networks with proper SSIDs, we don't have MAC address from the wifiscan library
-				// The wifiscan library doesn't provide MAC addresses directly in its API
-				macAddress = "N/A" // Not available in this scan method
-			}
+// collectWifiscanNetworks scans with the wifiscan library and converts
+// its results into WiFiNetwork, deduplicating by SSID the same way the
+// old inline fallback printing did.
+func collectWifiscanNetworks() ([]WiFiNetwork, error) {
+	scanned, err := wifiscan.Scan()
+	if err != nil {
+		return nil, err
+	}
 
-			// Calculate signal quality percentage (RSSI typically ranges from -100 to 0)
-			qualityPercentage := 0
-			if network.RSSI >= -30 {
-				qualityPercentage = 100
-			} else if network.RSSI <= -100 {
-				qualityPercentage = 0
-			} else {
-				qualityPercentage = 100 - (int(float64(network.RSSI+30) / -70.0 * 100.0))
-			}
+	sort.Slice(scanned, func(i, j int) bool {
+		return scanned[i].RSSI > scanned[j].RSSI
+	})
 
-			fmt.Printf("%-30s %-20s %-20s %-15s\n",
-				ssid,
-				macAddress,
-				fmt.Sprintf("%d dBm", network.RSSI),
-				fmt.Sprintf("%d%% (%s)", qualityPercentage, getSignalQualityLabel(qualityPercentage)))
+	var networks []WiFiNetwork
+	uniqueNetworks := make(map[string]bool)
+	for _, network := range scanned {
+		if uniqueNetworks[network.SSID] {
+			continue
+		}
+		uniqueNetworks[network.SSID] = true
+
+		ssid := network.SSID
+		macAddress := ""
+		if strings.Contains(ssid, ":") || isLikelyMacAddress(ssid) {
+			macAddress = ssid
+			ssid = "[Hidden Network]"
+		} else {
+			macAddress = "N/A" // not available from the wifiscan library
 		}
 
-		if len(networks) == 0 {
-			fmt.Println("No WiFi networks found. Make sure your WiFi adapter is enabled.")
+		qualityPercentage := 0
+		if network.RSSI >= -30 {
+			qualityPercentage = 100
+		} else if network.RSSI <= -100 {
+			qualityPercentage = 0
+		} else {
+			qualityPercentage = 100 - (int(float64(network.RSSI+30) / -70.0 * 100.0))
 		}
+
+		networks = append(networks, WiFiNetwork{
+			SSID:         ssid,
+			BSSID:        macAddress,
+			Signal:       qualityPercentage,
+			SignalDBm:    network.RSSI,
+			QualityLabel: getSignalQualityLabel(qualityPercentage),
+		})
 	}
+	return networks, nil
+}
+
+// toTUINetworks converts the main package's WiFiNetwork into the tui
+// package's renderer-facing Network type.
+func toTUINetworks(networks []WiFiNetwork) []tui.Network {
+	out := make([]tui.Network, 0, len(networks))
+	for _, n := range networks {
+		out = append(out, tui.Network{
+			SSID:          n.SSID,
+			BSSID:         n.BSSID,
+			Channel:       n.Channel,
+			SignalPercent: n.Signal,
+			SignalDBm:     n.SignalDBm,
+			QualityLabel:  n.QualityLabel,
+			InUse:         n.InUse,
+			Hidden:        n.SSID == "[Hidden Network]",
+		})
+	}
+	return out
 }
 
 // isLikelyMacAddress checks if the string looks like a MAC address without colons
@@ -455,16 +490,28 @@ func printConnectionQuality(downloadSpeed, uploadSpeed, latency float64) {
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: ./wifi-speed-cli [scan | speedtest]")
+		fmt.Println("Usage: ./wifi-speed-cli [scan | speedtest | monitor | benchmark | connect | serve]")
 		return
 	}
 
 	switch os.Args[1] {
 	case "scan":
-		scanWiFi()
+		if len(os.Args) > 2 && os.Args[2] == "--passive" {
+			runPassiveScan(os.Args[3:])
+		} else {
+			scanWiFi(os.Args[2:])
+		}
 	case "speedtest":
 		testSpeed()
+	case "monitor":
+		runMonitor(os.Args[2:])
+	case "benchmark":
+		runBenchmark(os.Args[2:])
+	case "connect":
+		runConnect(os.Args[2:])
+	case "serve":
+		runServe(os.Args[2:])
 	default:
-		fmt.Println("Invalid command. Use 'scan' to list Wi-Fi networks or 'speedtest' to check network speed.")
+		fmt.Println("Invalid command. Use 'scan', 'speedtest', 'monitor', 'benchmark', 'connect', or 'serve'.")
 	}
 }
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MaVeN-13TTN/wifi-speed-cli/wireless"
+)
+
+// runMonitor implements the `monitor` subcommand: it periodically samples
+// /proc/net/wireless and logs a time series of link quality, signal level,
+// noise floor, and discarded-packet counters so users can diagnose flaky
+// links between speed tests without needing root or nmcli.
+func runMonitor(args []string) {
+	fs := flag.NewFlagSet("monitor", flag.ExitOnError)
+	interval := fs.Duration("interval", 2*time.Second, "sampling interval")
+	duration := fs.Duration("duration", 0, "total monitoring duration (0 = run until interrupted)")
+	format := fs.String("format", "table", "output format: table, json, or csv")
+	iface := fs.String("iface", "", "only monitor this interface (default: all interfaces)")
+	fs.Parse(args)
+
+	var csvWriter *csv.Writer
+	switch *format {
+	case "csv":
+		csvWriter = csv.NewWriter(os.Stdout)
+		csvWriter.Write([]string{"timestamp", "interface", "link", "level_dbm", "noise_dbm", "quality", "nwid", "crypt", "frag", "retry", "misc", "missed"})
+		defer csvWriter.Flush()
+	case "table":
+		fmt.Printf("%-10s %-8s %-6s %-8s %-8s %-12s %s\n", "Time", "Iface", "Link", "Level", "Noise", "Quality", "Discards(nwid/crypt/frag/retry/misc)")
+	}
+
+	var deadline time.Time
+	if *duration > 0 {
+		deadline = time.Now().Add(*duration)
+	}
+
+	for {
+		samples, err := wireless.ReadProcNetWireless()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading wireless stats: %v\n", err)
+		} else {
+			now := time.Now()
+			for _, s := range samples {
+				if *iface != "" && s.Interface != *iface {
+					continue
+				}
+				printMonitorSample(now, s, *format, csvWriter)
+			}
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// printMonitorSample renders a single wireless sample in the requested
+// format, reusing getSignalQualityLabelFromPercent so the quality label
+// matches what `scan` reports.
+func printMonitorSample(ts time.Time, s wireless.Wireless, format string, csvWriter *csv.Writer) {
+	// Link quality in /proc/net/wireless is reported out of 70; normalize
+	// to a percentage so it lines up with the nmcli-derived signal scale.
+	percent := int(float64(s.Link) / 70.0 * 100)
+	if percent > 100 {
+		percent = 100
+	} else if percent < 0 {
+		percent = 0
+	}
+	label := getSignalQualityLabelFromPercent(percent)
+
+	switch format {
+	case "json":
+		record := map[string]interface{}{
+			"timestamp": ts.Format(time.RFC3339),
+			"interface": s.Interface,
+			"link":      s.Link,
+			"level_dbm": s.Level,
+			"noise_dbm": s.Noise,
+			"quality":   label,
+			"discards": map[string]int{
+				"nwid":  s.Nwid,
+				"crypt": s.Crypt,
+				"frag":  s.Frag,
+				"retry": s.Retry,
+				"misc":  s.Misc,
+			},
+			"missed_beacons": s.Missed,
+		}
+		enc, err := json.Marshal(record)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding sample: %v\n", err)
+			return
+		}
+		fmt.Println(string(enc))
+	case "csv":
+		csvWriter.Write([]string{
+			ts.Format(time.RFC3339),
+			s.Interface,
+			strconv.Itoa(s.Link),
+			strconv.Itoa(s.Level),
+			strconv.Itoa(s.Noise),
+			label,
+			strconv.Itoa(s.Nwid),
+			strconv.Itoa(s.Crypt),
+			strconv.Itoa(s.Frag),
+			strconv.Itoa(s.Retry),
+			strconv.Itoa(s.Misc),
+			strconv.Itoa(s.Missed),
+		})
+		csvWriter.Flush()
+	default:
+		fmt.Printf("%-10s %-8s %-6d %-8d %-8d %-12s %d/%d/%d/%d/%d\n",
+			ts.Format("15:04:05"), s.Interface, s.Link, s.Level, s.Noise, label,
+			s.Nwid, s.Crypt, s.Frag, s.Retry, s.Misc)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/MaVeN-13TTN/wifi-speed-cli/capture"
+)
+
+// runPassiveScan implements `scan --passive`: it puts the given interface
+// into monitor mode, sweeps it across channels with a capture.ChannelHopper,
+// and decodes beacon/probe-response frames directly with gopacket/pcap
+// instead of shelling out to nmcli. This catches hidden networks that
+// nmcli never reports.
+func runPassiveScan(args []string) {
+	fs := flag.NewFlagSet("scan --passive", flag.ExitOnError)
+	iface := fs.String("iface", "wlan0", "monitor-mode interface to capture on")
+	dwell := fs.Duration("dwell", capture.DefaultDwell, "time to dwell on each channel")
+	follow := fs.Bool("follow", false, "keep sweeping and updating results instead of stopping after one pass")
+	channelsFlag := fs.String("channels", "", "comma-separated 2.4/5 GHz channel numbers to sweep (default: capture.DefaultChannels)")
+	fs.Parse(args)
+
+	if !checkIsRoot() {
+		fmt.Println("❌ Error: passive scanning requires root privileges.")
+		fmt.Println("Please run the command with sudo: sudo ./wifi-speed-cli scan --passive")
+		return
+	}
+
+	channels := capture.DefaultChannels
+	if *channelsFlag != "" {
+		parsed, err := parseChannelList(*channelsFlag)
+		if err != nil {
+			fmt.Printf("Error parsing --channels: %v\n", err)
+			return
+		}
+		channels = parsed
+	}
+
+	fmt.Printf("Capturing on %s (passive, monitor mode)...\n", *iface)
+
+	restoreMode, err := capture.EnableMonitorMode(*iface)
+	if err != nil {
+		fmt.Printf("Error switching %s to monitor mode: %v\n", *iface, err)
+		return
+	}
+	defer func() {
+		if err := restoreMode(); err != nil {
+			fmt.Printf("Error restoring %s's original mode: %v\n", *iface, err)
+		}
+	}()
+
+	hopper := capture.NewChannelHopper(*iface, channels, *dwell)
+	scanner := capture.NewScanner(*iface)
+	stop := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if *follow {
+		// There's no bounded sweepDuration in follow mode, so the only
+		// way to end the sweep is Ctrl-C: cancel ctx, which stops the
+		// hopper and lets it close stop so Sweep can return what it's
+		// captured so far.
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigCh
+			fmt.Println("\nStopping sweep and printing captured results...")
+			cancel()
+		}()
+	}
+
+	go func() {
+		var err error
+		if *follow {
+			err = hopper.RunContinuous(ctx)
+		} else {
+			err = hopper.Run(ctx)
+		}
+		if err != nil && err != context.Canceled {
+			fmt.Printf("Error during channel hop: %v\n", err)
+		}
+		close(stop)
+	}()
+
+	sweepDuration := time.Duration(len(hopper.Channels)) * *dwell
+	if *follow {
+		sweepDuration = 0 // run until stop closes
+	}
+
+	observations, err := scanner.Sweep(sweepDuration, stop)
+	cancel()
+	if err != nil {
+		fmt.Printf("Error capturing frames: %v\n", err)
+		return
+	}
+
+	printPassiveScanResults(observations)
+}
+
+// parseChannelList parses a comma-separated list of channel numbers, e.g.
+// "1,6,11,36".
+func parseChannelList(s string) ([]int, error) {
+	fields := strings.Split(s, ",")
+	channels := make([]int, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		ch, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel %q: %w", f, err)
+		}
+		channels = append(channels, ch)
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("no channels given")
+	}
+	return channels, nil
+}
+
+func printPassiveScanResults(observations []capture.Observation) {
+	sort.Slice(observations, func(i, j int) bool {
+		return observations[i].RSSI > observations[j].RSSI
+	})
+
+	fmt.Println("Captured Wi-Fi Networks (passive):")
+	fmt.Println("-----------------------------------")
+	fmt.Printf("%-30s %-20s %-6s %-10s %-10s %-10s\n", "SSID", "BSSID", "Chan", "RSSI", "Security", "Rates(Mbps)")
+	fmt.Println("-------------------------------------------------------------------------------")
+
+	for _, o := range observations {
+		fmt.Printf("%-30s %-20s %-6d %-10s %-10s %-10s\n",
+			o.SSID, o.BSSID, o.Channel, fmt.Sprintf("%d dBm", o.RSSI), o.Security, strings.Join(o.Rates, ","))
+	}
+
+	if len(observations) == 0 {
+		fmt.Println("No frames captured. Make sure the interface is in monitor mode and within range of active APs.")
+	}
+}
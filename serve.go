@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/MaVeN-13TTN/wifi-speed-cli/wireless"
+)
+
+// metricsExporter holds every gauge/counter the `serve` subcommand
+// exposes on /metrics, reusing the same nmcli scan and
+// /proc/net/wireless parsing as `scan` and `monitor`.
+type metricsExporter struct {
+	registry *prometheus.Registry
+
+	apSignalDBm     *prometheus.GaugeVec
+	apSignalPercent *prometheus.GaugeVec
+	linkQuality     prometheus.Gauge
+	linkLevelDBm    prometheus.Gauge
+	linkNoiseDBm    prometheus.Gauge
+	discardedTotal  *prometheus.CounterVec
+
+	downloadMbps         prometheus.Gauge
+	uploadMbps           prometheus.Gauge
+	latencyMs            prometheus.Gauge
+	speedtestLastSuccess prometheus.Gauge
+
+	mu              sync.Mutex
+	discardedSeenAt map[string]int // last counter value observed per reason, so re-scrapes add only the delta
+}
+
+func newMetricsExporter() *metricsExporter {
+	e := &metricsExporter{
+		registry: prometheus.NewRegistry(),
+		apSignalDBm: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "wifi_ap_signal_dbm",
+			Help: "Signal strength of a visible access point, in dBm.",
+		}, []string{"ssid", "bssid", "channel"}),
+		apSignalPercent: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "wifi_ap_signal_percent",
+			Help: "Signal strength of a visible access point, as a percentage.",
+		}, []string{"ssid", "bssid", "channel"}),
+		linkQuality: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wifi_link_quality",
+			Help: "Current link quality of the active connection, as reported by /proc/net/wireless.",
+		}),
+		linkLevelDBm: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wifi_link_level_dbm",
+			Help: "Current signal level of the active connection, in dBm.",
+		}),
+		linkNoiseDBm: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wifi_link_noise_dbm",
+			Help: "Current noise floor of the active connection, in dBm.",
+		}),
+		discardedTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wifi_discarded_packets_total",
+			Help: "Discarded packets reported by /proc/net/wireless, by reason.",
+		}, []string{"reason"}),
+		downloadMbps: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wifi_download_mbps",
+			Help: "Download throughput from the most recent speed test, in Mbps.",
+		}),
+		uploadMbps: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wifi_upload_mbps",
+			Help: "Upload throughput from the most recent speed test, in Mbps.",
+		}),
+		latencyMs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wifi_latency_ms",
+			Help: "Latency from the most recent speed test, in milliseconds.",
+		}),
+		speedtestLastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wifi_speedtest_last_success_timestamp",
+			Help: "Unix timestamp of the last successful speed test.",
+		}),
+		discardedSeenAt: make(map[string]int),
+	}
+
+	e.registry.MustRegister(
+		e.apSignalDBm, e.apSignalPercent,
+		e.linkQuality, e.linkLevelDBm, e.linkNoiseDBm, e.discardedTotal,
+		e.downloadMbps, e.uploadMbps, e.latencyMs, e.speedtestLastSuccess,
+	)
+	return e
+}
+
+// refreshScanMetrics re-runs the nmcli scan and updates the per-AP
+// gauges. Stale series from APs that have dropped out of range are left
+// in place until the process restarts, matching how most Prometheus
+// textfile-style exporters behave.
+func (e *metricsExporter) refreshScanMetrics() {
+	candidates, err := scanCandidates(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error refreshing scan metrics: %v\n", err)
+		return
+	}
+	for _, c := range candidates {
+		labels := prometheus.Labels{"ssid": c.SSID, "bssid": c.BSSID, "channel": strconv.Itoa(c.Channel)}
+		e.apSignalDBm.With(labels).Set(float64(c.SignalDBm))
+		e.apSignalPercent.With(labels).Set(dbmToPercent(c.SignalDBm))
+	}
+}
+
+// dbmToPercent inverts the dBm conversion used by parseNetworkLine
+// (0% ~ -100 dBm, 100% ~ -40 dBm) so the exporter can publish a percent
+// gauge alongside the dBm one without re-scanning.
+func dbmToPercent(dbm int) float64 {
+	percent := (float64(dbm) + 100) * 100 / 60
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	return percent
+}
+
+// refreshWirelessMetrics re-reads /proc/net/wireless and updates the
+// active-link gauges plus the discarded-packet counters.
+func (e *metricsExporter) refreshWirelessMetrics() {
+	samples, err := wireless.ReadProcNetWireless()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error refreshing wireless metrics: %v\n", err)
+		return
+	}
+	if len(samples) == 0 {
+		return
+	}
+
+	s := samples[0]
+	e.linkQuality.Set(float64(s.Link))
+	e.linkLevelDBm.Set(float64(s.Level))
+	e.linkNoiseDBm.Set(float64(s.Noise))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.addDiscardDelta("nwid", s.Nwid)
+	e.addDiscardDelta("crypt", s.Crypt)
+	e.addDiscardDelta("frag", s.Frag)
+	e.addDiscardDelta("retry", s.Retry)
+	e.addDiscardDelta("misc", s.Misc)
+}
+
+// addDiscardDelta adds the increase in a monotonic /proc/net/wireless
+// counter since the last scrape to the corresponding Prometheus counter.
+func (e *metricsExporter) addDiscardDelta(reason string, value int) {
+	prev := e.discardedSeenAt[reason]
+	if value > prev {
+		e.discardedTotal.WithLabelValues(reason).Add(float64(value - prev))
+	}
+	e.discardedSeenAt[reason] = value
+}
+
+// runSpeedtestLoop runs a speed test every interval and publishes the
+// results to the exporter's gauges.
+func (e *metricsExporter) runSpeedtestLoop(interval time.Duration) {
+	for {
+		download, upload, latency, err := runSpeedTestWithLatency(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running scheduled speed test: %v\n", err)
+		} else {
+			e.downloadMbps.Set(download)
+			e.uploadMbps.Set(upload)
+			e.latencyMs.Set(latency)
+			e.speedtestLastSuccess.Set(float64(time.Now().Unix()))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// runServe implements the `serve` subcommand: a long-running HTTP server
+// exposing scan and link telemetry (and, optionally, periodic speed test
+// results) in Prometheus text format.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", ":9113", "address to listen on for /metrics")
+	speedtestInterval := fs.Duration("speedtest-interval", 30*time.Minute, "how often to run a background speed test")
+	noSpeedtest := fs.Bool("no-speedtest", false, "disable the background speed test loop")
+	fs.Parse(args)
+
+	exporter := newMetricsExporter()
+
+	if !*noSpeedtest {
+		go exporter.runSpeedtestLoop(*speedtestInterval)
+	}
+
+	handler := promhttp.HandlerFor(exporter.registry, promhttp.HandlerOpts{})
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		exporter.refreshScanMetrics()
+		exporter.refreshWirelessMetrics()
+		handler.ServeHTTP(w, r)
+	})
+
+	fmt.Printf("Serving Prometheus metrics on %s/metrics\n", *listen)
+	if err := http.ListenAndServe(*listen, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Error serving metrics: %v\n", err)
+	}
+}
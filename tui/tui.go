@@ -0,0 +1,144 @@
+// Package tui renders a colorized, sortable table of WiFi networks so
+// `scan`'s nmcli and wifiscan code paths can share one printing routine
+// instead of each formatting its own table.
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// ansi color/style codes used for RSSI coloring and row emphasis.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiRed    = "\x1b[31m"
+)
+
+// Network is the renderer's view of a WiFi network, shared by both the
+// nmcli and wifiscan scanning paths.
+type Network struct {
+	SSID          string
+	BSSID         string
+	SignalPercent int
+	SignalDBm     int
+	Channel       int // 0 if unknown (e.g. the wifiscan fallback doesn't report it)
+	QualityLabel  string
+	InUse         bool
+	Hidden        bool
+}
+
+// SortKey selects which column Render/Sort orders networks by.
+type SortKey string
+
+const (
+	SortSignal  SortKey = "signal"
+	SortSSID    SortKey = "ssid"
+	SortChannel SortKey = "channel"
+)
+
+// Options controls how Render formats the table.
+type Options struct {
+	NoColor bool
+	SortBy  SortKey
+	Reverse bool
+}
+
+// Sort orders networks in place according to opts.SortBy/opts.Reverse,
+// defaulting to strongest-signal-first when SortBy is empty or unknown.
+func Sort(networks []Network, opts Options) {
+	sort.SliceStable(networks, func(i, j int) bool {
+		var less bool
+		switch opts.SortBy {
+		case SortSSID:
+			less = networks[i].SSID < networks[j].SSID
+		case SortChannel:
+			less = networks[i].Channel < networks[j].Channel
+		default:
+			less = networks[i].SignalDBm > networks[j].SignalDBm
+		}
+		if opts.Reverse {
+			return !less
+		}
+		return less
+	})
+}
+
+// UseColor reports whether ANSI colors should be used: opts.NoColor
+// wasn't set and the given file is an interactive terminal.
+func UseColor(opts Options, f *os.File) bool {
+	return !opts.NoColor && isTerminal(f)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return (info.Mode() & os.ModeCharDevice) != 0
+}
+
+// Render writes a formatted table of networks to w. color enables ANSI
+// styling (RSSI coloring, bold for in-use networks, dim for hidden
+// ones); callers typically gate it with UseColor(opts, os.Stdout).
+func Render(w io.Writer, networks []Network, opts Options, color bool) {
+	sorted := make([]Network, len(networks))
+	copy(sorted, networks)
+	Sort(sorted, opts)
+
+	fmt.Fprintln(w, "Available Wi-Fi Networks:")
+	fmt.Fprintln(w, "-------------------------")
+	fmt.Fprintf(w, "%-30s %-20s %-20s %-15s\n", "SSID", "MAC Address", "Signal Strength", "Quality")
+	fmt.Fprintln(w, "-------------------------------------------------------------------------")
+
+	for _, n := range sorted {
+		fmt.Fprintln(w, formatRow(n, color))
+	}
+
+	if len(sorted) == 0 {
+		fmt.Fprintln(w, "No WiFi networks found. Make sure your WiFi adapter is enabled.")
+	}
+}
+
+func formatRow(n Network, color bool) string {
+	signalCol := fmt.Sprintf("%d%% (%d dBm)", n.SignalPercent, n.SignalDBm)
+	row := fmt.Sprintf("%-30s %-20s %-20s %-15s", n.SSID, n.BSSID, signalCol, n.QualityLabel)
+
+	if !color {
+		return row
+	}
+
+	row = rssiColor(n.SignalDBm) + row + ansiReset
+	if n.InUse {
+		row = ansiBold + row
+	}
+	if n.Hidden {
+		row = ansiDim + row
+	}
+	return row
+}
+
+// rssiColor mirrors bettercap's RSSI coloring: green at or above -60 dBm,
+// yellow between -60 and -75, red below -75.
+func rssiColor(dbm int) string {
+	switch {
+	case dbm >= -60:
+		return ansiGreen
+	case dbm >= -75:
+		return ansiYellow
+	default:
+		return ansiRed
+	}
+}
+
+// clearScreen returns the ANSI sequence to move the cursor to the top
+// left of the terminal and erase the screen, used by Watch to redraw in
+// place.
+func clearScreen() string {
+	return "\x1b[H\x1b[2J"
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// maxMissedRefreshes is how many consecutive refreshes a BSSID that has
+// dropped out of the scan is still rendered before it's forgotten
+// entirely; it is dimmed once it has been missing for two refreshes.
+const maxMissedRefreshes = 2
+
+// Watcher tracks, across successive refreshes, how recently each BSSID
+// has been seen so Watch can bold newly-appeared networks and dim ones
+// that have dropped out, airodump-style. It also remembers the last
+// observed data for a dropped-out BSSID so it can keep being rendered
+// for a couple of refreshes instead of vanishing the instant it's
+// absent from a scan.
+type Watcher struct {
+	missed map[string]int // refreshes since a BSSID was last observed
+	isNew  map[string]bool
+	last   map[string]Network // most recent data seen per BSSID
+}
+
+// NewWatcher returns an empty Watcher ready for its first refresh.
+func NewWatcher() *Watcher {
+	return &Watcher{missed: make(map[string]int), isNew: make(map[string]bool), last: make(map[string]Network)}
+}
+
+// observe folds one refresh's networks into the watcher's state and
+// returns the networks to render this frame (the current batch plus
+// any BSSID that dropped out within the last maxMissedRefreshes
+// refreshes), along with, per BSSID, whether it's new this refresh and
+// how many consecutive refreshes it's been missing for.
+func (wt *Watcher) observe(networks []Network) (rendered []Network, newBSSID map[string]bool, missedCount map[string]int) {
+	seen := make(map[string]bool, len(networks))
+	newBSSID = make(map[string]bool, len(networks))
+	for _, n := range networks {
+		seen[n.BSSID] = true
+		if _, known := wt.missed[n.BSSID]; !known {
+			newBSSID[n.BSSID] = true
+		}
+		wt.missed[n.BSSID] = 0
+		wt.last[n.BSSID] = n
+	}
+	for bssid := range wt.missed {
+		if seen[bssid] {
+			continue
+		}
+		wt.missed[bssid]++
+		if wt.missed[bssid] > maxMissedRefreshes {
+			delete(wt.missed, bssid)
+			delete(wt.last, bssid)
+		}
+	}
+
+	rendered = append(rendered, networks...)
+	for bssid, n := range wt.last {
+		if !seen[bssid] {
+			rendered = append(rendered, n)
+		}
+	}
+
+	missedCount = make(map[string]int, len(wt.missed))
+	for k, v := range wt.missed {
+		missedCount[k] = v
+	}
+	return rendered, newBSSID, missedCount
+}
+
+// Watch redraws the network table every interval using fetch to pull a
+// fresh set of networks, until stop is closed. Newly-appeared BSSIDs are
+// bolded for one refresh and BSSIDs missing for the last two refreshes
+// are dimmed, giving a live airodump-like view.
+func Watch(w io.Writer, fetch func() ([]Network, error), opts Options, color bool, interval time.Duration, stop <-chan struct{}) {
+	watcher := NewWatcher()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	render := func() {
+		networks, err := fetch()
+		if err != nil {
+			fmt.Fprintf(w, "Error refreshing networks: %v\n", err)
+			return
+		}
+		rendered, newBSSID, missedCount := watcher.observe(networks)
+
+		if color {
+			fmt.Fprint(w, clearScreen())
+		}
+		renderWatchFrame(w, rendered, opts, color, newBSSID, missedCount)
+	}
+
+	render()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			render()
+		}
+	}
+}
+
+func renderWatchFrame(w io.Writer, networks []Network, opts Options, color bool, newBSSID map[string]bool, missedCount map[string]int) {
+	sorted := make([]Network, len(networks))
+	copy(sorted, networks)
+	Sort(sorted, opts)
+
+	fmt.Fprintln(w, "Available Wi-Fi Networks (live):")
+	fmt.Fprintln(w, "---------------------------------")
+	fmt.Fprintf(w, "%-30s %-20s %-20s %-15s\n", "SSID", "MAC Address", "Signal Strength", "Quality")
+	fmt.Fprintln(w, "-------------------------------------------------------------------------")
+
+	for _, n := range sorted {
+		row := formatRow(n, color)
+		if color {
+			if newBSSID[n.BSSID] {
+				row = ansiBold + row
+			} else if missedCount[n.BSSID] >= 2 {
+				row = ansiDim + row
+			}
+		}
+		fmt.Fprintln(w, row)
+	}
+
+	if len(sorted) == 0 {
+		fmt.Fprintln(w, "No WiFi networks found. Make sure your WiFi adapter is enabled.")
+	}
+}
@@ -0,0 +1,128 @@
+// Package wireless parses the kernel's /proc/net/wireless table, which
+// exposes per-interface link quality and discard counters without
+// requiring root or a NetworkManager dependency.
+package wireless
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Wireless holds the link-quality and discard counters for a single
+// interface as reported by /proc/net/wireless.
+type Wireless struct {
+	Interface string
+	Status    uint64
+	Link      int
+	Level     int
+	Noise     int
+	Nwid      int
+	Crypt     int
+	Frag      int
+	Retry     int
+	Misc      int
+	Missed    int
+}
+
+// ReadProcNetWireless opens /proc/net/wireless and returns one Wireless
+// entry per interface listed in the file.
+func ReadProcNetWireless() ([]Wireless, error) {
+	f, err := os.Open("/proc/net/wireless")
+	if err != nil {
+		return nil, fmt.Errorf("opening /proc/net/wireless: %w", err)
+	}
+	defer f.Close()
+
+	return parseProcNetWireless(f)
+}
+
+// parseProcNetWireless parses the /proc/net/wireless format:
+//
+//	Inter-| sta-|   Link  Level  Noise    Nwid  Crypt   Frag  Retry   Misc  Missed  WE
+//	 face  |status| quality        dBm       |     discarded packets               beacon
+//	 wlan0: 0000   70.  -40.  -256        0      0      0      0      0        0
+//
+// The first two lines are headers and are skipped.
+func parseProcNetWireless(r io.Reader) ([]Wireless, error) {
+	scanner := bufio.NewScanner(r)
+	var entries []Wireless
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		if lineNum <= 2 {
+			continue
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		entry, err := parseWirelessLine(line)
+		if err != nil {
+			// Skip malformed lines rather than failing the whole read.
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading /proc/net/wireless: %w", err)
+	}
+	return entries, nil
+}
+
+func parseWirelessLine(line string) (Wireless, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 11 {
+		return Wireless{}, fmt.Errorf("not enough fields in wireless line")
+	}
+
+	w := Wireless{
+		Interface: strings.TrimSuffix(fields[0], ":"),
+	}
+
+	status, err := strconv.ParseUint(fields[1], 16, 64)
+	if err != nil {
+		return Wireless{}, fmt.Errorf("parsing status word: %w", err)
+	}
+	w.Status = status
+
+	link, err := strconv.Atoi(strings.TrimSuffix(fields[2], "."))
+	if err != nil {
+		return Wireless{}, fmt.Errorf("parsing link quality: %w", err)
+	}
+	w.Link = link
+
+	level, err := strconv.Atoi(strings.TrimSuffix(fields[3], "."))
+	if err != nil {
+		return Wireless{}, fmt.Errorf("parsing signal level: %w", err)
+	}
+	w.Level = level
+
+	noise, err := strconv.Atoi(strings.TrimSuffix(fields[4], "."))
+	if err != nil {
+		return Wireless{}, fmt.Errorf("parsing noise level: %w", err)
+	}
+	w.Noise = noise
+
+	// Columns 5-9 are the discarded-packet counters (nwid, crypt, frag,
+	// retry, misc) and column 10 is the missed-beacon count.
+	discards := make([]int, 6)
+	for i := range discards {
+		v, err := strconv.Atoi(strings.TrimSuffix(fields[5+i], "."))
+		if err != nil {
+			return Wireless{}, fmt.Errorf("parsing discard counter: %w", err)
+		}
+		discards[i] = v
+	}
+	w.Nwid = discards[0]
+	w.Crypt = discards[1]
+	w.Frag = discards[2]
+	w.Retry = discards[3]
+	w.Misc = discards[4]
+	w.Missed = discards[5]
+
+	return w, nil
+}
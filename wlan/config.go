@@ -0,0 +1,61 @@
+package wlan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KnownNetwork is one entry in the user's known.yaml: an SSID the user
+// trusts, its PSK, and optional constraints on which APs to join.
+type KnownNetwork struct {
+	SSID         string `yaml:"ssid"`
+	PSK          string `yaml:"psk"`
+	MinSignalDBm int    `yaml:"min_signal_dbm,omitempty"`
+	BSSID        string `yaml:"bssid,omitempty"` // pin to a specific AP, if set
+}
+
+// DefaultKnownNetworksPath returns ~/.config/wifi-speed-cli/known.yaml.
+func DefaultKnownNetworksPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "wifi-speed-cli", "known.yaml"), nil
+}
+
+// LoadKnownNetworks reads a known.yaml file into a map keyed by SSID.
+func LoadKnownNetworks(path string) (map[string]KnownNetwork, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading known networks file %s: %w", path, err)
+	}
+
+	var entries []KnownNetwork
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing known networks file %s: %w", path, err)
+	}
+
+	known := make(map[string]KnownNetwork, len(entries))
+	for _, e := range entries {
+		known[e.SSID] = e
+	}
+	return known, nil
+}
+
+// Matches reports whether a candidate satisfies this known network's
+// minimum signal and BSSID-pinning constraints.
+func (k KnownNetwork) Matches(c Candidate) bool {
+	if k.SSID != c.SSID {
+		return false
+	}
+	if k.MinSignalDBm != 0 && c.SignalDBm < k.MinSignalDBm {
+		return false
+	}
+	if k.BSSID != "" && k.BSSID != c.BSSID {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package wlan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BSSIDRecord tracks the running average of the speed test results seen
+// on a given BSSID, so future connection attempts can prefer APs that historically
+// delivered better throughput rather than just the strongest RSSI.
+type BSSIDRecord struct {
+	BSSID        string  `yaml:"bssid"`
+	SSID         string  `yaml:"ssid"`
+	DownloadMbps float64 `yaml:"download_mbps"`
+	UploadMbps   float64 `yaml:"upload_mbps"`
+	Samples      int     `yaml:"samples"`
+}
+
+// DefaultHistoryPath returns ~/.config/wifi-speed-cli/history.yaml.
+func DefaultHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "wifi-speed-cli", "history.yaml"), nil
+}
+
+// LoadHistory reads the per-BSSID history file, returning an empty map
+// if it doesn't exist yet.
+func LoadHistory(path string) (map[string]BSSIDRecord, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return make(map[string]BSSIDRecord), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history file %s: %w", path, err)
+	}
+
+	var records []BSSIDRecord
+	if err := yaml.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parsing history file %s: %w", path, err)
+	}
+
+	history := make(map[string]BSSIDRecord, len(records))
+	for _, r := range records {
+		history[r.BSSID] = r
+	}
+	return history, nil
+}
+
+// RecordResult folds a new speed test result into the BSSID's running
+// average and persists the updated history file.
+func RecordResult(path, bssid, ssid string, downloadMbps, uploadMbps float64) error {
+	history, err := LoadHistory(path)
+	if err != nil {
+		return err
+	}
+
+	existing, ok := history[bssid]
+	if !ok {
+		existing = BSSIDRecord{BSSID: bssid, SSID: ssid}
+	}
+	existing.SSID = ssid
+	existing.DownloadMbps = runningAverage(existing.DownloadMbps, downloadMbps, existing.Samples)
+	existing.UploadMbps = runningAverage(existing.UploadMbps, uploadMbps, existing.Samples)
+	existing.Samples++
+	history[bssid] = existing
+
+	return saveHistory(path, history)
+}
+
+func runningAverage(current, sample float64, samples int) float64 {
+	if samples == 0 {
+		return sample
+	}
+	return (current*float64(samples) + sample) / float64(samples+1)
+}
+
+func saveHistory(path string, history map[string]BSSIDRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	records := make([]BSSIDRecord, 0, len(history))
+	for _, r := range history {
+		records = append(records, r)
+	}
+
+	data, err := yaml.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("encoding history file: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing history file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package wlan implements a small auto-connect state machine, modeled
+// after Fuchsia's wlan/states.go: each state knows how to run itself and
+// how to react to being kept around too long, and the machine just walks
+// from state to state until it lands on a terminal one.
+package wlan
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StateName identifies one node in the connection state machine.
+type StateName string
+
+const (
+	StateScanning       StateName = "scanning"
+	StateJoining        StateName = "joining"
+	StateAuthenticating StateName = "authenticating"
+	StateAssociated     StateName = "associated"
+	StateFailed         StateName = "failed"
+	StateIdle           StateName = "idle"
+)
+
+// State is a single node in the connection state machine.
+type State interface {
+	Name() StateName
+	// Run performs the state's work and returns the next state to
+	// transition to, or nil if the machine should stop.
+	Run(ctx context.Context) (State, error)
+	// HandleTimeout is called if Run doesn't return before its deadline
+	// and returns the state to fall back to.
+	HandleTimeout() State
+}
+
+// Candidate is an access point discovered during the scanning state.
+type Candidate struct {
+	SSID      string
+	BSSID     string
+	Channel   int // 0 if unknown
+	SignalDBm int
+}
+
+// Machine drives a State graph to completion, stopping at the first
+// terminal state (associated, failed, or idle).
+type Machine struct {
+	Current State
+	// StateTimeout bounds how long a single state is given to run before
+	// HandleTimeout is invoked instead.
+	StateTimeout time.Duration
+}
+
+// NewMachine builds a Machine starting in the given state.
+func NewMachine(start State, stateTimeout time.Duration) *Machine {
+	if stateTimeout <= 0 {
+		stateTimeout = 30 * time.Second
+	}
+	return &Machine{Current: start, StateTimeout: stateTimeout}
+}
+
+// Run drives the machine until a state reports it's done (by returning a
+// nil next state) or ctx is canceled. Terminal states (associated,
+// failed, idle) do their work in Run like any other state and then
+// return nil to stop the machine.
+func (m *Machine) Run(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("state %s: %w", m.Current.Name(), err)
+		}
+		next, err := m.runOnce(ctx)
+		if err != nil {
+			return fmt.Errorf("state %s: %w", m.Current.Name(), err)
+		}
+		if next == nil {
+			return nil
+		}
+		m.Current = next
+	}
+}
+
+func (m *Machine) runOnce(ctx context.Context) (State, error) {
+	stateCtx, cancel := context.WithTimeout(ctx, m.StateTimeout)
+	defer cancel()
+
+	type result struct {
+		next State
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		next, err := m.Current.Run(stateCtx)
+		done <- result{next, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.next, r.err
+	case <-stateCtx.Done():
+		return m.Current.HandleTimeout(), nil
+	}
+}
+
+// IsTerminal reports whether name is one of the machine's stopping
+// states (associated, failed, idle).
+func IsTerminal(name StateName) bool {
+	return name == StateAssociated || name == StateFailed || name == StateIdle
+}
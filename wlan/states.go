@@ -0,0 +1,245 @@
+package wlan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Hooks lets the caller plug in the actual scanning, connecting, and
+// speed-testing behavior without this package needing to know about
+// nmcli or speedtest-go directly.
+type Hooks struct {
+	// Scan enumerates currently visible access points.
+	Scan func(ctx context.Context) ([]Candidate, error)
+	// Connect attempts to join the given SSID with the given PSK.
+	Connect func(ctx context.Context, ssid, psk string) error
+	// TestSpeed runs a speed test against the now-active connection.
+	TestSpeed func(ctx context.Context) (downloadMbps, uploadMbps float64, err error)
+}
+
+// baseBackoff is the initial delay between join attempts; it doubles
+// after each failed candidate.
+const baseBackoff = 2 * time.Second
+
+// NewScanningState builds the entry point of the auto-connect state
+// machine: it scans for candidates and filters them against the known
+// network list. stateTimeout must match the Machine's per-state timeout
+// so the joining state can keep its inter-candidate backoff from ever
+// exceeding the time it's given to run.
+func NewScanningState(hooks Hooks, known map[string]KnownNetwork, history map[string]BSSIDRecord, historyPath string, stateTimeout time.Duration) State {
+	return &scanningState{hooks: hooks, known: known, history: history, historyPath: historyPath, stateTimeout: stateTimeout}
+}
+
+type scanningState struct {
+	hooks        Hooks
+	known        map[string]KnownNetwork
+	history      map[string]BSSIDRecord
+	historyPath  string
+	stateTimeout time.Duration
+}
+
+func (s *scanningState) Name() StateName { return StateScanning }
+
+func (s *scanningState) Run(ctx context.Context) (State, error) {
+	candidates, err := s.hooks.Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("scanning for candidates: %w", err)
+	}
+
+	var matched []Candidate
+	for _, c := range candidates {
+		if kn, ok := s.known[c.SSID]; ok && kn.Matches(c) {
+			matched = append(matched, c)
+		}
+	}
+	if len(matched) == 0 {
+		return &failedState{reason: "no known networks in range"}, nil
+	}
+
+	sortByPreference(matched, s.history)
+
+	return &joiningState{
+		hooks:        s.hooks,
+		known:        s.known,
+		history:      s.history,
+		historyPath:  s.historyPath,
+		candidates:   matched,
+		stateTimeout: s.stateTimeout,
+	}, nil
+}
+
+func (s *scanningState) HandleTimeout() State {
+	return &failedState{reason: "scan timed out"}
+}
+
+// sortByPreference orders candidates by their historical throughput
+// first (so a known-good BSSID beats a merely-stronger one) and falls
+// back to raw signal strength for BSSIDs with no history yet.
+func sortByPreference(candidates []Candidate, history map[string]BSSIDRecord) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, hasI := history[candidates[i].BSSID]
+		rj, hasJ := history[candidates[j].BSSID]
+		if hasI && hasJ {
+			return ri.DownloadMbps > rj.DownloadMbps
+		}
+		if hasI != hasJ {
+			return hasI // prefer the BSSID we have history for
+		}
+		return candidates[i].SignalDBm > candidates[j].SignalDBm
+	})
+}
+
+type joiningState struct {
+	hooks        Hooks
+	known        map[string]KnownNetwork
+	history      map[string]BSSIDRecord
+	historyPath  string
+	candidates   []Candidate
+	attempt      int
+	stateTimeout time.Duration
+}
+
+func (j *joiningState) Name() StateName { return StateJoining }
+
+func (j *joiningState) Run(ctx context.Context) (State, error) {
+	if j.attempt >= len(j.candidates) {
+		return &failedState{reason: "exhausted all known candidates"}, nil
+	}
+
+	if j.attempt > 0 {
+		select {
+		case <-time.After(j.backoff()):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	candidate := j.candidates[j.attempt]
+	known := j.known[candidate.SSID]
+
+	if err := j.hooks.Connect(ctx, candidate.SSID, known.PSK); err != nil {
+		j.attempt++
+		return j, nil
+	}
+
+	return &authenticatingState{
+		hooks:       j.hooks,
+		historyPath: j.historyPath,
+		candidate:   candidate,
+	}, nil
+}
+
+func (j *joiningState) HandleTimeout() State {
+	j.attempt++
+	return j
+}
+
+// backoff computes the inter-attempt delay, doubling with each failed
+// candidate but capped at half of stateTimeout so it can never consume
+// the whole per-state deadline and starve hooks.Connect of time to
+// actually run. Without this cap, candidates past the 5th or so would
+// never get a real connection attempt: HandleTimeout would keep firing
+// before time.After(backoff) ever did.
+func (j *joiningState) backoff() time.Duration {
+	backoff := baseBackoff * time.Duration(1<<uint(j.attempt-1))
+	if j.stateTimeout > 0 {
+		if ceiling := j.stateTimeout / 2; backoff > ceiling {
+			backoff = ceiling
+		}
+	}
+	return backoff
+}
+
+type authenticatingState struct {
+	hooks       Hooks
+	historyPath string
+	candidate   Candidate
+}
+
+func (a *authenticatingState) Name() StateName { return StateAuthenticating }
+
+func (a *authenticatingState) Run(ctx context.Context) (State, error) {
+	// nmcli's `connect` call already blocks until the device reports
+	// connected or failed, so by the time we're here authentication has
+	// succeeded; this state exists to keep the machine's shape aligned
+	// with a real 802.11 handshake and to give HandleTimeout somewhere
+	// to fall back to if that assumption ever changes.
+	return &associatedState{hooks: a.hooks, historyPath: a.historyPath, candidate: a.candidate}, nil
+}
+
+func (a *authenticatingState) HandleTimeout() State {
+	return &failedState{reason: "authentication timed out"}
+}
+
+type associatedState struct {
+	hooks       Hooks
+	historyPath string
+	candidate   Candidate
+}
+
+func (a *associatedState) Name() StateName { return StateAssociated }
+
+func (a *associatedState) Run(ctx context.Context) (State, error) {
+	download, upload, err := a.hooks.TestSpeed(ctx)
+	if err != nil {
+		// We're still connected even if the speed test failed, so this
+		// isn't a connection failure; just skip the history update.
+		return nil, nil
+	}
+	if a.historyPath != "" {
+		if err := RecordResult(a.historyPath, a.candidate.BSSID, a.candidate.SSID, download, upload); err != nil {
+			return nil, fmt.Errorf("recording BSSID history: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+func (a *associatedState) HandleTimeout() State {
+	return a
+}
+
+type failedState struct {
+	reason string
+}
+
+func (f *failedState) Name() StateName { return StateFailed }
+
+func (f *failedState) Run(ctx context.Context) (State, error) {
+	return nil, nil
+}
+
+func (f *failedState) HandleTimeout() State {
+	return f
+}
+
+// Reason returns the human-readable explanation recorded when the
+// machine landed in the failed state.
+func (f *failedState) Reason() string { return f.reason }
+
+// FailureReason extracts the reason from a failed terminal state, if
+// the machine stopped in StateFailed.
+func FailureReason(s State) (string, bool) {
+	f, ok := s.(*failedState)
+	if !ok {
+		return "", false
+	}
+	return f.reason, true
+}
+
+type idleState struct{}
+
+// NewIdleState returns the machine's quiescent starting/ending state: it
+// does nothing and immediately stops the machine.
+func NewIdleState() State { return &idleState{} }
+
+func (i *idleState) Name() StateName { return StateIdle }
+
+func (i *idleState) Run(ctx context.Context) (State, error) {
+	return nil, nil
+}
+
+func (i *idleState) HandleTimeout() State {
+	return i
+}